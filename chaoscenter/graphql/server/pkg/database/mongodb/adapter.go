@@ -0,0 +1,42 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ErrNotFound is returned by DbAdapter methods that look up or update a
+// document by ID when no document matches it, so callers get the same error
+// to branch on (errors.Is(err, ErrNotFound)) no matter which implementation -
+// InMemoryAdapter or MongoDbAdapter - is behind the interface.
+var ErrNotFound = errors.New("not found")
+
+// DbAdapter exposes the database operations resolvers need as typed,
+// domain-shaped methods instead of handing out raw *mongo.Collection values.
+// Confining every bson/mongo.Collection call to an implementation of this
+// interface lets resolvers be unit-tested against InMemoryAdapter without a
+// running mongod, and keeps the door open to a non-Mongo backend (FerretDB,
+// DocumentDB) later without touching resolver code.
+type DbAdapter interface {
+	InsertExperiment(ctx context.Context, experiment interface{}) error
+	ListExperimentsByProject(ctx context.Context, projectID string) ([]bson.M, error)
+	UpdateExperiment(ctx context.Context, experimentID string, update bson.M) error
+
+	InsertExperimentRun(ctx context.Context, run interface{}) error
+	ListExperimentRunsByExperiment(ctx context.Context, experimentID string) ([]bson.M, error)
+
+	// CreateExperimentWithRun inserts experiment and its initial run
+	// together, atomically - a failure partway through (e.g. the run insert
+	// failing right after the experiment insert commits) must not leave an
+	// orphan document of either kind behind.
+	CreateExperimentWithRun(ctx context.Context, experiment, run interface{}) error
+
+	UpsertInfra(ctx context.Context, infraID string, infra interface{}) error
+	GetInfraByID(ctx context.Context, infraID string) (bson.M, error)
+
+	UpsertHub(ctx context.Context, hubID string, hub interface{}) error
+	GetHubByID(ctx context.Context, hubID string) (bson.M, error)
+	ListHubsByProject(ctx context.Context, projectID string) ([]bson.M, error)
+}
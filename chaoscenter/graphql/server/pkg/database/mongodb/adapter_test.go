@@ -0,0 +1,262 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// adapterCase exercises one behavior against a DbAdapter, independent of
+// which concrete implementation backs it, so InMemoryAdapter and
+// MongoDbAdapter are checked against the same contract instead of drifting
+// apart unnoticed.
+type adapterCase struct {
+	name string
+	run  func(t *testing.T, adapter DbAdapter)
+}
+
+func insertAndListExperiments(t *testing.T, adapter DbAdapter) {
+	ctx := context.Background()
+
+	if err := adapter.InsertExperiment(ctx, bson.M{"experiment_id": "exp-1", "project_id": "proj-1", "name": "exp-one"}); err != nil {
+		t.Fatalf("InsertExperiment: %v", err)
+	}
+
+	experiments, err := adapter.ListExperimentsByProject(ctx, "proj-1")
+	if err != nil {
+		t.Fatalf("ListExperimentsByProject: %v", err)
+	}
+	if len(experiments) != 1 || experiments[0]["experiment_id"] != "exp-1" {
+		t.Fatalf("ListExperimentsByProject = %+v, want one experiment with id exp-1", experiments)
+	}
+}
+
+func insertAndListExperimentRuns(t *testing.T, adapter DbAdapter) {
+	ctx := context.Background()
+
+	if err := adapter.InsertExperimentRun(ctx, bson.M{"experiment_run_id": "run-1", "experiment_id": "exp-2"}); err != nil {
+		t.Fatalf("InsertExperimentRun: %v", err)
+	}
+
+	runs, err := adapter.ListExperimentRunsByExperiment(ctx, "exp-2")
+	if err != nil {
+		t.Fatalf("ListExperimentRunsByExperiment: %v", err)
+	}
+	if len(runs) != 1 || runs[0]["experiment_run_id"] != "run-1" {
+		t.Fatalf("ListExperimentRunsByExperiment = %+v, want one run with id run-1", runs)
+	}
+}
+
+func upsertAndGetInfra(t *testing.T, adapter DbAdapter) {
+	ctx := context.Background()
+
+	if err := adapter.UpsertInfra(ctx, "infra-1", bson.M{"infra_id": "infra-1", "name": "infra-one"}); err != nil {
+		t.Fatalf("UpsertInfra: %v", err)
+	}
+
+	infra, err := adapter.GetInfraByID(ctx, "infra-1")
+	if err != nil {
+		t.Fatalf("GetInfraByID: %v", err)
+	}
+	if infra["infra_id"] != "infra-1" {
+		t.Fatalf("GetInfraByID = %+v, want infra_id infra-1", infra)
+	}
+}
+
+func upsertAndGetHub(t *testing.T, adapter DbAdapter) {
+	ctx := context.Background()
+
+	if err := adapter.UpsertHub(ctx, "hub-1", bson.M{"hub_id": "hub-1", "name": "hub-one"}); err != nil {
+		t.Fatalf("UpsertHub: %v", err)
+	}
+
+	hub, err := adapter.GetHubByID(ctx, "hub-1")
+	if err != nil {
+		t.Fatalf("GetHubByID: %v", err)
+	}
+	if hub["hub_id"] != "hub-1" {
+		t.Fatalf("GetHubByID = %+v, want hub_id hub-1", hub)
+	}
+}
+
+// createExperimentWithRunInsertsBoth exercises the happy path of
+// CreateExperimentWithRun against InMemoryAdapter; MongoDbAdapter's
+// transactional path isn't covered here since mtest's mock client doesn't
+// model session/transaction commands.
+func createExperimentWithRunInsertsBoth(t *testing.T, adapter DbAdapter) {
+	ctx := context.Background()
+
+	experiment := bson.M{"experiment_id": "exp-3", "project_id": "proj-1", "name": "exp-three"}
+	run := bson.M{"experiment_run_id": "run-3", "experiment_id": "exp-3"}
+
+	if err := adapter.CreateExperimentWithRun(ctx, experiment, run); err != nil {
+		t.Fatalf("CreateExperimentWithRun: %v", err)
+	}
+
+	experiments, err := adapter.ListExperimentsByProject(ctx, "proj-1")
+	if err != nil {
+		t.Fatalf("ListExperimentsByProject: %v", err)
+	}
+	if len(experiments) != 1 || experiments[0]["experiment_id"] != "exp-3" {
+		t.Fatalf("ListExperimentsByProject = %+v, want one experiment with id exp-3", experiments)
+	}
+
+	runs, err := adapter.ListExperimentRunsByExperiment(ctx, "exp-3")
+	if err != nil {
+		t.Fatalf("ListExperimentRunsByExperiment: %v", err)
+	}
+	if len(runs) != 1 || runs[0]["experiment_run_id"] != "run-3" {
+		t.Fatalf("ListExperimentRunsByExperiment = %+v, want one run with id run-3", runs)
+	}
+}
+
+// createExperimentWithRunRollsBackOnRunFailure asserts InMemoryAdapter's
+// rollback: a run insert that fails (missing experiment_id) must not leave
+// the experiment it was paired with behind.
+func createExperimentWithRunRollsBackOnRunFailure(t *testing.T, adapter DbAdapter) {
+	ctx := context.Background()
+
+	experiment := bson.M{"experiment_id": "exp-4", "project_id": "proj-1", "name": "exp-four"}
+	invalidRun := bson.M{"experiment_run_id": "run-4"} // missing experiment_id
+
+	if err := adapter.CreateExperimentWithRun(ctx, experiment, invalidRun); err == nil {
+		t.Fatalf("CreateExperimentWithRun with an invalid run = nil error, want an error")
+	}
+
+	experiments, err := adapter.ListExperimentsByProject(ctx, "proj-1")
+	if err != nil {
+		t.Fatalf("ListExperimentsByProject: %v", err)
+	}
+	for _, exp := range experiments {
+		if exp["experiment_id"] == "exp-4" {
+			t.Fatalf("ListExperimentsByProject = %+v, want exp-4 rolled back after its run insert failed", experiments)
+		}
+	}
+}
+
+// updateMissingExperimentReturnsNotFound asserts ErrNotFound the same way
+// regardless of backend - MongoDbAdapter's UpdateOne succeeding with no
+// matches must not look like success to the caller.
+func updateMissingExperimentReturnsNotFound(t *testing.T, adapter DbAdapter) {
+	err := adapter.UpdateExperiment(context.Background(), "missing-exp", bson.M{"name": "renamed"})
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("UpdateExperiment(missing-exp) = %v, want ErrNotFound", err)
+	}
+}
+
+// getMissingInfraReturnsNotFound asserts ErrNotFound the same way regardless
+// of backend - MongoDbAdapter must translate the driver's mongo.ErrNoDocuments
+// rather than leaking it.
+func getMissingInfraReturnsNotFound(t *testing.T, adapter DbAdapter) {
+	_, err := adapter.GetInfraByID(context.Background(), "missing-infra")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("GetInfraByID(missing-infra) = %v, want ErrNotFound", err)
+	}
+}
+
+// getMissingHubReturnsNotFound is getMissingInfraReturnsNotFound's hub
+// counterpart.
+func getMissingHubReturnsNotFound(t *testing.T, adapter DbAdapter) {
+	_, err := adapter.GetHubByID(context.Background(), "missing-hub")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("GetHubByID(missing-hub) = %v, want ErrNotFound", err)
+	}
+}
+
+// TestInMemoryAdapter runs the shared cases against InMemoryAdapter directly.
+func TestInMemoryAdapter(t *testing.T) {
+	cases := []adapterCase{
+		{name: "insert and list experiments by project", run: insertAndListExperiments},
+		{name: "insert and list experiment runs", run: insertAndListExperimentRuns},
+		{name: "upsert and get infra", run: upsertAndGetInfra},
+		{name: "upsert and get hub", run: upsertAndGetHub},
+		{name: "create experiment with run inserts both", run: createExperimentWithRunInsertsBoth},
+		{name: "create experiment with run rolls back on run failure", run: createExperimentWithRunRollsBackOnRunFailure},
+		{name: "update missing experiment returns not found", run: updateMissingExperimentReturnsNotFound},
+		{name: "get missing infra returns not found", run: getMissingInfraReturnsNotFound},
+		{name: "get missing hub returns not found", run: getMissingHubReturnsNotFound},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			c.run(t, NewInMemoryAdapter())
+		})
+	}
+}
+
+// TestMongoDbAdapter runs the shared cases, except the two
+// CreateExperimentWithRun ones, against MongoDbAdapter backed by mtest's mock
+// mongod, so the driver calls MongoDbAdapter actually issues are
+// checked, not just InMemoryAdapter's map bookkeeping.
+func TestMongoDbAdapter(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	newAdapter := func(mt *mtest.T) DbAdapter {
+		return NewMongoDbAdapter(&MongoClient{
+			ChaosExperimentCollection:     mt.Coll,
+			ChaosExperimentRunsCollection: mt.Coll,
+			ChaosInfraCollection:          mt.Coll,
+			ChaosHubCollection:            mt.Coll,
+		})
+	}
+
+	mt.Run("insert and list experiments by project", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		mt.AddMockResponses(mtest.CreateCursorResponse(1, "db.coll", mtest.FirstBatch,
+			bson.D{{Key: "experiment_id", Value: "exp-1"}, {Key: "project_id", Value: "proj-1"}}))
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.coll", mtest.NextBatch))
+
+		insertAndListExperiments(t, newAdapter(mt))
+	})
+
+	mt.Run("insert and list experiment runs", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		mt.AddMockResponses(mtest.CreateCursorResponse(1, "db.coll", mtest.FirstBatch,
+			bson.D{{Key: "experiment_run_id", Value: "run-1"}, {Key: "experiment_id", Value: "exp-2"}}))
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.coll", mtest.NextBatch))
+
+		insertAndListExperimentRuns(t, newAdapter(mt))
+	})
+
+	mt.Run("upsert and get infra", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		mt.AddMockResponses(mtest.CreateCursorResponse(1, "db.coll", mtest.FirstBatch,
+			bson.D{{Key: "infra_id", Value: "infra-1"}, {Key: "name", Value: "infra-one"}}))
+
+		upsertAndGetInfra(t, newAdapter(mt))
+	})
+
+	mt.Run("upsert and get hub", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		mt.AddMockResponses(mtest.CreateCursorResponse(1, "db.coll", mtest.FirstBatch,
+			bson.D{{Key: "hub_id", Value: "hub-1"}, {Key: "name", Value: "hub-one"}}))
+
+		upsertAndGetHub(t, newAdapter(mt))
+	})
+
+	mt.Run("update missing experiment returns not found", func(mt *mtest.T) {
+		mt.AddMockResponses(bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "n", Value: 0},
+			{Key: "nModified", Value: 0},
+		})
+
+		updateMissingExperimentReturnsNotFound(t, newAdapter(mt))
+	})
+
+	mt.Run("get missing infra returns not found", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.coll", mtest.FirstBatch))
+
+		getMissingInfraReturnsNotFound(t, newAdapter(mt))
+	})
+
+	mt.Run("get missing hub returns not found", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.coll", mtest.FirstBatch))
+
+		getMissingHubReturnsNotFound(t, newAdapter(mt))
+	})
+}
@@ -0,0 +1,199 @@
+package mongodb
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"os"
+	"strconv"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/metrics"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/utils"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+// MongoConnection builds a *mongo.Client from utils.Config. Beyond the
+// server/user/password every deployment needs, it honors the full connection
+// profile required to run against production-hardened clusters: replica-set
+// membership, TLS (including X.509 client certs), read/write concerns, and
+// connection-pool sizing. It pings with the configured read preference so a
+// misconfigured replica set or an unreachable secondary fails fast at
+// startup instead of surfacing as confusing errors from the first query.
+//
+// It also wires up Mongo instrumentation: if no Collector has been set via
+// SetMetrics yet, it registers one against prometheus.DefaultRegisterer (the
+// registry the existing /metrics endpoint serves) so every MongoDbAdapter
+// operation and the driver's own command latency are observable without
+// every deployment needing to call SetMetrics itself.
+func MongoConnection() (*mongo.Client, error) {
+	if Metrics == nil {
+		SetMetrics(metrics.NewCollector(prometheus.DefaultRegisterer))
+	}
+
+	dbServer := utils.Config.DbServer
+	if dbServer == "" {
+		return nil, errors.New("DB configuration failed")
+	}
+
+	clientOptions := options.Client().ApplyURI(dbServer)
+
+	if err := applyAuth(clientOptions); err != nil {
+		return nil, err
+	}
+
+	if utils.Config.ReplicaSet != "" {
+		clientOptions.SetReplicaSet(utils.Config.ReplicaSet)
+	}
+
+	if err := applyTLS(clientOptions); err != nil {
+		return nil, err
+	}
+
+	applyConcerns(clientOptions)
+
+	if Metrics != nil {
+		clientOptions.SetMonitor(Metrics.CommandMonitor())
+	}
+
+	if utils.Config.MaxPoolSize > 0 {
+		clientOptions.SetMaxPoolSize(uint64(utils.Config.MaxPoolSize))
+	}
+	if utils.Config.MinPoolSize > 0 {
+		clientOptions.SetMinPoolSize(uint64(utils.Config.MinPoolSize))
+	}
+
+	client, err := mongo.Connect(backgroundContext, clientOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	readPreference, err := parseReadPreference(utils.Config.ReadPreference)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(backgroundContext, ConnectionTimeout)
+	defer cancel()
+
+	// Check the connection
+	if err := client.Ping(ctx, readPreference); err != nil {
+		return nil, err
+	}
+
+	logrus.Infof("connected to mongo")
+
+	return client, nil
+}
+
+// applyAuth configures credentials on clientOptions. MONGODB-X509 relies
+// entirely on the TLS client certificate, so no username/password is
+// required for it; every other mechanism (the default is SCRAM-SHA-256)
+// still requires both.
+func applyAuth(clientOptions *options.ClientOptions) error {
+	if utils.Config.AuthMechanism == "MONGODB-X509" {
+		clientOptions.SetAuth(options.Credential{AuthMechanism: "MONGODB-X509"})
+		return nil
+	}
+
+	dbUser := utils.Config.DbUser
+	dbPassword := utils.Config.DbPassword
+	if dbUser == "" || dbPassword == "" {
+		return errors.New("DB configuration failed")
+	}
+
+	clientOptions.SetAuth(options.Credential{
+		AuthMechanism: utils.Config.AuthMechanism,
+		Username:      dbUser,
+		Password:      dbPassword,
+	})
+
+	return nil
+}
+
+// applyTLS wires up TLSEnabled/TLSCAFile/TLSCertificateKeyFile/TLSInsecure
+// from utils.Config onto clientOptions. It is a no-op when TLS isn't enabled.
+func applyTLS(clientOptions *options.ClientOptions) error {
+	if !utils.Config.TLSEnabled {
+		return nil
+	}
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: utils.Config.TLSInsecure}
+
+	if utils.Config.TLSCAFile != "" {
+		caCert, err := os.ReadFile(utils.Config.TLSCAFile)
+		if err != nil {
+			return err
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return errors.New("failed to parse mongo TLS CA file")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if utils.Config.TLSCertificateKeyFile != "" {
+		// The driver's tlsCertificateKeyFile convention is a single PEM file
+		// containing both the client certificate and its private key.
+		cert, err := tls.LoadX509KeyPair(utils.Config.TLSCertificateKeyFile, utils.Config.TLSCertificateKeyFile)
+		if err != nil {
+			return err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	clientOptions.SetTLSConfig(tlsConfig)
+
+	return nil
+}
+
+// applyConcerns sets the read/write concern on clientOptions. A replica set
+// defaults to w=majority when WriteConcern isn't set explicitly, matching
+// the driver's own recommendation for production deployments.
+func applyConcerns(clientOptions *options.ClientOptions) {
+	if utils.Config.ReadConcern != "" {
+		clientOptions.SetReadConcern(readconcern.New(readconcern.Level(utils.Config.ReadConcern)))
+	}
+
+	wc := utils.Config.WriteConcern
+	if wc == "" && utils.Config.ReplicaSet != "" {
+		wc = "majority"
+	}
+
+	switch {
+	case wc == "":
+		return
+	case wc == "majority":
+		clientOptions.SetWriteConcern(writeconcern.New(writeconcern.WMajority()))
+	default:
+		if w, err := strconv.Atoi(wc); err == nil {
+			clientOptions.SetWriteConcern(writeconcern.New(writeconcern.W(w)))
+		} else {
+			clientOptions.SetWriteConcern(writeconcern.New(writeconcern.WTagSet(wc)))
+		}
+	}
+}
+
+// parseReadPreference translates utils.Config.ReadPreference ("primary",
+// "secondaryPreferred", ...) into a *readpref.ReadPref, defaulting to primary
+// when unset so existing deployments keep their current behavior.
+func parseReadPreference(mode string) (*readpref.ReadPref, error) {
+	if mode == "" {
+		return readpref.Primary(), nil
+	}
+
+	readMode, err := readpref.ModeFromString(mode)
+	if err != nil {
+		return nil, err
+	}
+
+	return readpref.New(readMode)
+}
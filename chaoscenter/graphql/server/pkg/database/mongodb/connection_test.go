@@ -0,0 +1,120 @@
+package mongodb
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/utils"
+
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+// withConfig runs fn with utils.Config's replica-set/read-concern/write-concern
+// fields set to the given values, restoring the previous values afterwards -
+// applyConcerns reads straight off the package-wide utils.Config instead of
+// taking parameters.
+func withConfig(t *testing.T, replicaSet, readConcern, writeConcern string, fn func()) {
+	t.Helper()
+
+	origReplicaSet := utils.Config.ReplicaSet
+	origReadConcern := utils.Config.ReadConcern
+	origWriteConcern := utils.Config.WriteConcern
+	defer func() {
+		utils.Config.ReplicaSet = origReplicaSet
+		utils.Config.ReadConcern = origReadConcern
+		utils.Config.WriteConcern = origWriteConcern
+	}()
+
+	utils.Config.ReplicaSet = replicaSet
+	utils.Config.ReadConcern = readConcern
+	utils.Config.WriteConcern = writeConcern
+
+	fn()
+}
+
+func TestApplyConcernsWriteConcernSwitch(t *testing.T) {
+	cases := []struct {
+		name         string
+		replicaSet   string
+		writeConcern string
+		want         *writeconcern.WriteConcern
+	}{
+		{
+			name: "no replica set, no write concern configured",
+			want: nil,
+		},
+		{
+			name:       "replica set defaults to majority",
+			replicaSet: "rs0",
+			want:       writeconcern.New(writeconcern.WMajority()),
+		},
+		{
+			name:         "explicit majority",
+			writeConcern: "majority",
+			want:         writeconcern.New(writeconcern.WMajority()),
+		},
+		{
+			name:         "numeric write concern",
+			writeConcern: "2",
+			want:         writeconcern.New(writeconcern.W(2)),
+		},
+		{
+			name:         "tag-set write concern",
+			writeConcern: "customTagSet",
+			want:         writeconcern.New(writeconcern.WTagSet("customTagSet")),
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			withConfig(t, c.replicaSet, "", c.writeConcern, func() {
+				clientOptions := options.Client()
+				applyConcerns(clientOptions)
+
+				if !reflect.DeepEqual(clientOptions.WriteConcern, c.want) {
+					t.Fatalf("WriteConcern = %+v, want %+v", clientOptions.WriteConcern, c.want)
+				}
+			})
+		})
+	}
+}
+
+func TestApplyConcernsReadConcern(t *testing.T) {
+	withConfig(t, "", "majority", "", func() {
+		clientOptions := options.Client()
+		applyConcerns(clientOptions)
+
+		want := readconcern.New(readconcern.Level("majority"))
+		if !reflect.DeepEqual(clientOptions.ReadConcern, want) {
+			t.Fatalf("ReadConcern = %+v, want %+v", clientOptions.ReadConcern, want)
+		}
+	})
+}
+
+func TestParseReadPreference(t *testing.T) {
+	rp, err := parseReadPreference("")
+	if err != nil {
+		t.Fatalf("parseReadPreference(\"\") returned an error: %v", err)
+	}
+	if rp.Mode() != readpref.PrimaryMode {
+		t.Fatalf("parseReadPreference(\"\") mode = %v, want Primary (the documented default)", rp.Mode())
+	}
+
+	rp, err = parseReadPreference("secondaryPreferred")
+	if err != nil {
+		t.Fatalf("parseReadPreference(secondaryPreferred) returned an error: %v", err)
+	}
+	if rp.Mode() != readpref.SecondaryPreferredMode {
+		t.Fatalf("parseReadPreference(secondaryPreferred) mode = %v, want SecondaryPreferred", rp.Mode())
+	}
+}
+
+func TestParseReadPreferenceUnrecognizedModeErrors(t *testing.T) {
+	if _, err := parseReadPreference("not-a-real-mode"); err == nil {
+		t.Fatalf("parseReadPreference(not-a-real-mode) returned a nil error, want an error for an unrecognized mode")
+	}
+}
@@ -2,15 +2,13 @@ package mongodb
 
 import (
 	"context"
-	"errors"
 	"time"
 
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/watcher"
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/utils"
 
 	"github.com/sirupsen/logrus"
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // Enum for Database collections
@@ -48,6 +46,12 @@ type MongoClient struct {
 	UserCollection                *mongo.Collection
 	ProjectCollection             *mongo.Collection
 	EnvironmentCollection         *mongo.Collection
+
+	// Watcher drives GraphQL subscriptions off MongoDB change streams instead
+	// of the subscription resolvers' previous polling loops. It's only built
+	// and started against a replica set (change streams require one), so it
+	// stays nil on a standalone deployment.
+	Watcher *watcher.Manager
 }
 
 var (
@@ -71,43 +75,6 @@ var (
 	backgroundContext = context.Background()
 )
 
-func MongoConnection() (*mongo.Client, error) {
-	var (
-		dbServer   = utils.Config.DbServer
-		dbUser     = utils.Config.DbUser
-		dbPassword = utils.Config.DbPassword
-	)
-
-	if dbServer == "" || dbUser == "" || dbPassword == "" {
-		return nil, errors.New("DB configuration failed")
-	}
-
-	credential := options.Credential{
-		Username: dbUser,
-		Password: dbPassword,
-	}
-
-	clientOptions := options.Client().ApplyURI(dbServer).SetAuth(credential)
-
-	client, err := mongo.Connect(backgroundContext, clientOptions)
-	if err != nil {
-		return nil, err
-	}
-
-	ctx, cancel := context.WithTimeout(backgroundContext, ConnectionTimeout)
-	defer cancel()
-
-	// Check the connection
-	err = client.Ping(ctx, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	logrus.Infof("connected to mongo")
-
-	return client, nil
-}
-
 // Initialize initializes database connection
 func (m *MongoClient) Initialize(client *mongo.Client) *MongoClient {
 	m.Database = client.Database(DbName)
@@ -116,141 +83,40 @@ func (m *MongoClient) Initialize(client *mongo.Client) *MongoClient {
 	return m
 }
 
-// initAllCollection initializes all the database collections
+// initAllCollection initializes all the database collections. Collection and
+// index creation is delegated to EnsureCollections, which reconciles the live
+// database against the declarative collectionSpecs table instead of
+// unconditionally issuing CreateCollection/CreateMany on every startup - safe
+// for multiple chaoscenter replicas to run concurrently.
 func (m *MongoClient) initAllCollection() {
-	m.UserCollection = m.Database.Collection(Collections[UserCollection])
-	m.ProjectCollection = m.Database.Collection(Collections[ProjectCollection])
-
-	// Initialize chaos infra collection
-	err := m.Database.CreateCollection(context.TODO(), Collections[ChaosInfraCollection], nil)
-	if err != nil {
-		logrus.WithError(err).Error("failed to create chaosInfrastructures collection")
+	if err := m.EnsureCollections(backgroundContext, collectionSpecs()); err != nil {
+		logrus.WithError(err).Fatal("failed to reconcile mongo collections/indexes")
 	}
 
 	m.ChaosInfraCollection = m.Database.Collection(Collections[ChaosInfraCollection])
-	_, err = m.ChaosInfraCollection.Indexes().CreateMany(backgroundContext, []mongo.IndexModel{
-		{
-			Keys: bson.M{
-				"infra_id": 1,
-			},
-			Options: options.Index().SetUnique(true),
-		},
-		{
-			Keys: bson.M{
-				"name": 1,
-			},
-		},
-	})
-	if err != nil {
-		logrus.WithError(err).Error("failed to create indexes for chaosInfrastructures collection")
-	}
-
-	// Initialize chaos experiment collection
-	err = m.Database.CreateCollection(context.TODO(), Collections[ChaosExperimentCollection], nil)
-	if err != nil {
-		logrus.WithError(err).Error("failed to create chaosExperiments collection")
-	}
-
 	m.ChaosExperimentCollection = m.Database.Collection(Collections[ChaosExperimentCollection])
-	_, err = m.ChaosExperimentCollection.Indexes().CreateMany(backgroundContext, []mongo.IndexModel{
-		{
-			Keys: bson.M{
-				"experiment_id": 1,
-			},
-			Options: options.Index().SetUnique(true),
-		},
-		{
-			Keys: bson.M{
-				"name": 1,
-			},
-		},
-	})
-	if err != nil {
-		logrus.WithError(err).Error("failed to create indexes for chaosExperiments collection")
-	}
-
-	// Initialize chaos experiment runs collection
-	err = m.Database.CreateCollection(context.TODO(), Collections[ChaosExperimentRunsCollection], nil)
-	if err != nil {
-		logrus.WithError(err).Error("failed to create chaosExperimentRuns collection")
-	}
-
 	m.ChaosExperimentRunsCollection = m.Database.Collection(Collections[ChaosExperimentRunsCollection])
-	_, err = m.ChaosExperimentRunsCollection.Indexes().CreateMany(backgroundContext, []mongo.IndexModel{
-		{
-			Keys: bson.M{
-				"experiment_run_id": 1,
-			},
-		},
-	})
-	if err != nil {
-		logrus.WithError(err).Fatal("failed to create indexes for chaosExperimentRuns collection")
-	}
-
-	// Initialize chaos hubs collection
-	err = m.Database.CreateCollection(context.TODO(), Collections[ChaosHubCollection], nil)
-	if err != nil {
-		logrus.WithError(err).Error("failed to create chaosHubs collection")
-	}
-
 	m.ChaosHubCollection = m.Database.Collection(Collections[ChaosHubCollection])
-	_, err = m.ChaosHubCollection.Indexes().CreateMany(backgroundContext, []mongo.IndexModel{
-		{
-			Keys: bson.M{
-				"hub_id": 1,
-			},
-			Options: options.Index().SetUnique(true),
-		},
-		{
-			Keys: bson.M{
-				"name": 1,
-			},
-		},
-	})
-	if err != nil {
-		logrus.WithError(err).Fatal("failed to create indexes for chaosHubs collection")
-	}
-
 	m.GitOpsCollection = m.Database.Collection(Collections[GitOpsCollection])
-	_, err = m.GitOpsCollection.Indexes().CreateMany(backgroundContext, []mongo.IndexModel{
-		{
-			Keys: bson.M{
-				"project_id": 1,
-			},
-			Options: options.Index().SetUnique(true),
-		},
-	})
-	if err != nil {
-		logrus.WithError(err).Fatal("Error Creating Index for GitOps Collection : ", err)
-	}
 	m.ImageRegistryCollection = m.Database.Collection(Collections[ImageRegistryCollection])
 	m.ServerConfigCollection = m.Database.Collection(Collections[ServerConfigCollection])
-	_, err = m.ServerConfigCollection.Indexes().CreateMany(backgroundContext, []mongo.IndexModel{
-		{
-			Keys: bson.M{
-				"key": 1,
-			},
-			Options: options.Index().SetUnique(true),
-		},
-	})
-	if err != nil {
-		logrus.WithError(err).Fatal("Error Creating Index for Server Config Collection : ", err)
-	}
 	m.EnvironmentCollection = m.Database.Collection(Collections[EnvironmentCollection])
-	_, err = m.EnvironmentCollection.Indexes().CreateMany(backgroundContext, []mongo.IndexModel{
-		{
-			Keys: bson.M{
-				"environment_id": 1,
-			},
-			Options: options.Index().SetUnique(true),
-		},
-		{
-			Keys: bson.M{
-				"name": 1,
-			},
-		},
-	})
-	if err != nil {
-		logrus.WithError(err).Fatal("failed to create indexes for environments collection")
+	m.UserCollection = m.Database.Collection(Collections[UserCollection])
+	m.ProjectCollection = m.Database.Collection(Collections[ProjectCollection])
+
+	m.startWatcher()
+}
+
+// startWatcher builds a watcher.Manager over the collections subscription
+// resolvers care about - experiment runs (status/node-phase changes) and
+// infra (heartbeats) - and starts it, so those resolvers can move off their
+// polling loops onto m.Watcher.Bus.Subscribe. Change streams require a
+// replica set, so this is a no-op (m.Watcher stays nil) everywhere else.
+func (m *MongoClient) startWatcher() {
+	if utils.Config.ReplicaSet == "" {
+		return
 	}
+
+	m.Watcher = watcher.NewManager(m.ServerConfigCollection, m.ChaosExperimentRunsCollection, m.ChaosInfraCollection)
+	m.Watcher.Start(backgroundContext)
 }
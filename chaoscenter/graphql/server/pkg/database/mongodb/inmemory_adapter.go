@@ -0,0 +1,212 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var _ DbAdapter = (*InMemoryAdapter)(nil)
+
+// InMemoryAdapter is a DbAdapter backed by in-process maps instead of a real
+// MongoDB connection. It exists so resolver unit tests can exercise business
+// logic without spinning up mongod; it only supports the lookups DbAdapter
+// promises and is not a general-purpose query engine.
+type InMemoryAdapter struct {
+	mu             sync.Mutex
+	experiments    map[string]bson.M
+	experimentRuns map[string][]bson.M
+	infra          map[string]bson.M
+	hubs           map[string]bson.M
+}
+
+// NewInMemoryAdapter returns an empty InMemoryAdapter ready for use in tests.
+func NewInMemoryAdapter() *InMemoryAdapter {
+	return &InMemoryAdapter{
+		experiments:    make(map[string]bson.M),
+		experimentRuns: make(map[string][]bson.M),
+		infra:          make(map[string]bson.M),
+		hubs:           make(map[string]bson.M),
+	}
+}
+
+// toBsonM round-trips v through bson so callers can pass the same structs
+// they'd pass to a *mongo.Collection and get back the same bson.M shape
+// MongoDbAdapter would hand resolvers.
+func toBsonM(v interface{}) (bson.M, error) {
+	data, err := bson.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var m bson.M
+	if err := bson.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (a *InMemoryAdapter) InsertExperiment(ctx context.Context, experiment interface{}) error {
+	doc, err := toBsonM(experiment)
+	if err != nil {
+		return err
+	}
+
+	id, _ := doc["experiment_id"].(string)
+	if id == "" {
+		return errors.New("experiment missing experiment_id")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.experiments[id] = doc
+
+	return nil
+}
+
+func (a *InMemoryAdapter) ListExperimentsByProject(ctx context.Context, projectID string) ([]bson.M, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var result []bson.M
+	for _, exp := range a.experiments {
+		if pid, _ := exp["project_id"].(string); pid == projectID {
+			result = append(result, exp)
+		}
+	}
+
+	return result, nil
+}
+
+func (a *InMemoryAdapter) UpdateExperiment(ctx context.Context, experimentID string, update bson.M) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	exp, ok := a.experiments[experimentID]
+	if !ok {
+		return ErrNotFound
+	}
+
+	for k, v := range update {
+		exp[k] = v
+	}
+
+	return nil
+}
+
+func (a *InMemoryAdapter) InsertExperimentRun(ctx context.Context, run interface{}) error {
+	doc, err := toBsonM(run)
+	if err != nil {
+		return err
+	}
+
+	experimentID, _ := doc["experiment_id"].(string)
+	if experimentID == "" {
+		return errors.New("experiment run missing experiment_id")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.experimentRuns[experimentID] = append(a.experimentRuns[experimentID], doc)
+
+	return nil
+}
+
+// CreateExperimentWithRun inserts experiment then run, rolling the
+// experiment insert back if the run insert fails - InMemoryAdapter has no
+// real transactions, but it still has to uphold the "no orphan experiment or
+// run" contract MongoDbAdapter's WithTransaction-backed implementation gives.
+func (a *InMemoryAdapter) CreateExperimentWithRun(ctx context.Context, experiment, run interface{}) error {
+	if err := a.InsertExperiment(ctx, experiment); err != nil {
+		return err
+	}
+
+	if err := a.InsertExperimentRun(ctx, run); err != nil {
+		doc, bsonErr := toBsonM(experiment)
+		if bsonErr == nil {
+			if experimentID, _ := doc["experiment_id"].(string); experimentID != "" {
+				a.mu.Lock()
+				delete(a.experiments, experimentID)
+				a.mu.Unlock()
+			}
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (a *InMemoryAdapter) ListExperimentRunsByExperiment(ctx context.Context, experimentID string) ([]bson.M, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.experimentRuns[experimentID], nil
+}
+
+func (a *InMemoryAdapter) UpsertInfra(ctx context.Context, infraID string, infra interface{}) error {
+	doc, err := toBsonM(infra)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.infra[infraID] = doc
+
+	return nil
+}
+
+func (a *InMemoryAdapter) GetInfraByID(ctx context.Context, infraID string) (bson.M, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	infra, ok := a.infra[infraID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return infra, nil
+}
+
+func (a *InMemoryAdapter) UpsertHub(ctx context.Context, hubID string, hub interface{}) error {
+	doc, err := toBsonM(hub)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.hubs[hubID] = doc
+
+	return nil
+}
+
+func (a *InMemoryAdapter) GetHubByID(ctx context.Context, hubID string) (bson.M, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	hub, ok := a.hubs[hubID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return hub, nil
+}
+
+func (a *InMemoryAdapter) ListHubsByProject(ctx context.Context, projectID string) ([]bson.M, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var result []bson.M
+	for _, hub := range a.hubs {
+		if pid, _ := hub["project_id"].(string); pid == projectID {
+			result = append(result, hub)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,19 @@
+package mongodb
+
+import "github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb/metrics"
+
+// Metrics is the Collector instrumenting every Mongo operation with
+// Prometheus histograms/counters and OpenTelemetry spans. It stays nil -
+// leaving MongoDbAdapter and MongoConnection uninstrumented - until
+// SetMetrics is called. MongoConnection calls it automatically against
+// prometheus.DefaultRegisterer, the registry the existing /metrics
+// endpoint's promhttp.Handler serves, unless a caller has already set one;
+// call SetMetrics before MongoConnection to register against a different
+// registerer instead.
+var Metrics *metrics.Collector
+
+// SetMetrics wires collector into every subsequent MongoDbAdapter operation
+// and MongoConnection's driver CommandMonitor.
+func SetMetrics(collector *metrics.Collector) {
+	Metrics = collector
+}
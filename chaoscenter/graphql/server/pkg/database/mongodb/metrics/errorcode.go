@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"errors"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Well-known MongoDB server error codes worth naming in metric labels instead
+// of leaving them as bare numbers.
+const (
+	codeDuplicateKey    = 11000
+	codeNamespaceExists = 48
+	codeWriteConflict   = 112
+)
+
+var codeNames = map[int]string{
+	codeDuplicateKey:    "duplicate_key",
+	codeNamespaceExists: "namespace_exists",
+	codeWriteConflict:   "write_conflict",
+}
+
+// errorCode extracts a label-friendly bucket for err: a known code's name,
+// the raw numeric code if unrecognized, or "unknown" for non-Mongo errors.
+func errorCode(err error) string {
+	if code, ok := serverErrorCode(err); ok {
+		if name, ok := codeNames[code]; ok {
+			return name
+		}
+		return strconv.Itoa(code)
+	}
+
+	return "unknown"
+}
+
+func serverErrorCode(err error) (int, bool) {
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) {
+		return int(cmdErr.Code), true
+	}
+
+	var writeException mongo.WriteException
+	if errors.As(err, &writeException) {
+		if len(writeException.WriteErrors) > 0 {
+			return writeException.WriteErrors[0].Code, true
+		}
+		if writeException.WriteConcernError != nil {
+			return writeException.WriteConcernError.Code, true
+		}
+	}
+
+	var bulkErr mongo.BulkWriteException
+	if errors.As(err, &bulkErr) && len(bulkErr.WriteErrors) > 0 {
+		return bulkErr.WriteErrors[0].Code, true
+	}
+
+	return 0, false
+}
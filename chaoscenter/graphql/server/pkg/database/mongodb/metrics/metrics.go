@@ -0,0 +1,116 @@
+// Package metrics instruments every Mongo operation chaoscenter issues with
+// Prometheus histograms/counters and OpenTelemetry spans, so slow queries and
+// index regressions show up to operators instead of being discovered via
+// user reports.
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const namespace = "litmus"
+const subsystem = "mongo"
+
+var tracer = otel.Tracer("github.com/litmuschaos/litmus/chaoscenter/graphql/server/pkg/database/mongodb")
+
+// Collector holds every metric emitted around Mongo operations. It is safe
+// for concurrent use and is normally created once per process via
+// NewCollector and registered with the existing /metrics handler's registerer.
+type Collector struct {
+	opDuration *prometheus.HistogramVec
+	opErrors   *prometheus.CounterVec
+}
+
+// NewCollector builds a Collector and registers its metrics with reg.
+func NewCollector(reg prometheus.Registerer) *Collector {
+	c := &Collector{
+		opDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "op_duration_seconds",
+			Help:      "Duration of Mongo operations, by collection, operation, and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"collection", "op", "status"}),
+		opErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "op_errors_total",
+			Help:      "Mongo operation errors, bucketed by server error code.",
+		}, []string{"collection", "op", "code"}),
+	}
+
+	reg.MustRegister(c.opDuration, c.opErrors)
+
+	return c
+}
+
+// Track runs fn, timing it into opDuration, counting any error into
+// opErrors keyed by its Mongo error code, and wrapping it in an OpenTelemetry
+// span carrying the collection name, operation, and the *shape* of filter
+// (its keys, not its values, to avoid leaking document contents into traces).
+func (c *Collector) Track(ctx context.Context, collection, op string, filter interface{}, fn func(ctx context.Context) error) error {
+	ctx, span := tracer.Start(ctx, "mongo."+op, trace.WithAttributes(
+		attribute.String("db.mongodb.collection", collection),
+		attribute.String("db.operation", op),
+		attribute.StringSlice("db.mongodb.filter_keys", filterKeys(filter)),
+	))
+	defer span.End()
+
+	start := time.Now()
+	err := fn(ctx)
+	duration := time.Since(start).Seconds()
+
+	status := "ok"
+	if err != nil {
+		status = "error"
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		c.opErrors.WithLabelValues(collection, op, errorCode(err)).Inc()
+	}
+
+	c.opDuration.WithLabelValues(collection, op, status).Observe(duration)
+
+	return err
+}
+
+// filterKeys returns the top-level keys of a bson filter document, used as a
+// low-cardinality, value-free span attribute.
+func filterKeys(filter interface{}) []string {
+	doc, err := toBsonM(filter)
+	if err != nil {
+		return nil
+	}
+
+	keys := make([]string, 0, len(doc))
+	for k := range doc {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
+
+func toBsonM(v interface{}) (bson.M, error) {
+	if v == nil {
+		return bson.M{}, nil
+	}
+
+	data, err := bson.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var m bson.M
+	if err := bson.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
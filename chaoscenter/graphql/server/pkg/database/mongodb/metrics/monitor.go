@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// CommandMonitor returns an *event.CommandMonitor that feeds driver-level
+// command latency into c's op_duration_seconds histogram under the
+// "_driver" pseudo-collection, independent of (and in addition to) the
+// per-call timing Track does around individual DbAdapter operations. Wire it
+// in via options.Client().SetMonitor(...) when building the Mongo client so
+// command latency is captured uniformly, including for commands DbAdapter
+// doesn't wrap directly.
+func (c *Collector) CommandMonitor() *event.CommandMonitor {
+	var starts sync.Map // event.RequestID -> time.Time
+
+	return &event.CommandMonitor{
+		Started: func(_ context.Context, e *event.CommandStartedEvent) {
+			starts.Store(e.RequestID, time.Now())
+		},
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			c.observeCommand(&starts, e.RequestID, e.CommandName, "ok")
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			c.observeCommand(&starts, e.RequestID, e.CommandName, "error")
+		},
+	}
+}
+
+func (c *Collector) observeCommand(starts *sync.Map, requestID int64, command, status string) {
+	startedAt, ok := starts.LoadAndDelete(requestID)
+	if !ok {
+		return
+	}
+
+	c.opDuration.WithLabelValues("_driver", command, status).Observe(time.Since(startedAt.(time.Time)).Seconds())
+}
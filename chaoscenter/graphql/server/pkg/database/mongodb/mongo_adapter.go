@@ -0,0 +1,193 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var _ DbAdapter = (*MongoDbAdapter)(nil)
+
+// MongoDbAdapter is the production DbAdapter, backed by a real MongoClient.
+// Every bson/mongo.Collection specific call lives here so the rest of the
+// codebase only ever talks to the DbAdapter interface.
+type MongoDbAdapter struct {
+	client *MongoClient
+}
+
+// NewMongoDbAdapter wraps an initialized MongoClient as a DbAdapter.
+func NewMongoDbAdapter(client *MongoClient) *MongoDbAdapter {
+	return &MongoDbAdapter{client: client}
+}
+
+// track runs fn through Metrics, when configured, timing it and recording it
+// as collection/op; with no Metrics collector set it just runs fn directly.
+func (a *MongoDbAdapter) track(ctx context.Context, collection, op string, filter interface{}, fn func(ctx context.Context) error) error {
+	if Metrics == nil {
+		return fn(ctx)
+	}
+
+	return Metrics.Track(ctx, collection, op, filter, fn)
+}
+
+// InsertExperiment inserts a new chaos experiment document.
+func (a *MongoDbAdapter) InsertExperiment(ctx context.Context, experiment interface{}) error {
+	return a.track(ctx, Collections[ChaosExperimentCollection], "InsertOne", experiment, func(ctx context.Context) error {
+		_, err := a.client.ChaosExperimentCollection.InsertOne(ctx, experiment)
+		return err
+	})
+}
+
+// ListExperimentsByProject returns every experiment belonging to projectID.
+func (a *MongoDbAdapter) ListExperimentsByProject(ctx context.Context, projectID string) ([]bson.M, error) {
+	filter := bson.M{"project_id": projectID}
+
+	var experiments []bson.M
+	err := a.track(ctx, Collections[ChaosExperimentCollection], "Find", filter, func(ctx context.Context) error {
+		cursor, err := a.client.ChaosExperimentCollection.Find(ctx, filter)
+		if err != nil {
+			return err
+		}
+		return cursor.All(ctx, &experiments)
+	})
+
+	return experiments, err
+}
+
+// UpdateExperiment applies a $set update to the experiment matching
+// experimentID, returning ErrNotFound when it matches nothing - UpdateOne
+// succeeding is not by itself proof that a document existed to update.
+func (a *MongoDbAdapter) UpdateExperiment(ctx context.Context, experimentID string, update bson.M) error {
+	filter := bson.M{"experiment_id": experimentID}
+
+	var matchedCount int64
+	err := a.track(ctx, Collections[ChaosExperimentCollection], "UpdateOne", filter, func(ctx context.Context) error {
+		result, err := a.client.ChaosExperimentCollection.UpdateOne(ctx, filter, bson.M{"$set": update})
+		if err != nil {
+			return err
+		}
+		matchedCount = result.MatchedCount
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if matchedCount == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// InsertExperimentRun inserts a new chaos experiment run document.
+func (a *MongoDbAdapter) InsertExperimentRun(ctx context.Context, run interface{}) error {
+	return a.track(ctx, Collections[ChaosExperimentRunsCollection], "InsertOne", run, func(ctx context.Context) error {
+		_, err := a.client.ChaosExperimentRunsCollection.InsertOne(ctx, run)
+		return err
+	})
+}
+
+// ListExperimentRunsByExperiment returns every run recorded for experimentID.
+func (a *MongoDbAdapter) ListExperimentRunsByExperiment(ctx context.Context, experimentID string) ([]bson.M, error) {
+	filter := bson.M{"experiment_id": experimentID}
+
+	var runs []bson.M
+	err := a.track(ctx, Collections[ChaosExperimentRunsCollection], "Find", filter, func(ctx context.Context) error {
+		cursor, err := a.client.ChaosExperimentRunsCollection.Find(ctx, filter)
+		if err != nil {
+			return err
+		}
+		return cursor.All(ctx, &runs)
+	})
+
+	return runs, err
+}
+
+// CreateExperimentWithRun inserts experiment and run inside a single
+// WithTransaction call, so a failure partway through - e.g. the run insert
+// failing after the experiment insert already committed - can't leave an
+// orphan document behind the way two sequential, unguarded inserts could.
+func (a *MongoDbAdapter) CreateExperimentWithRun(ctx context.Context, experiment, run interface{}) error {
+	return a.client.WithTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+		if err := a.InsertExperiment(sessCtx, experiment); err != nil {
+			return err
+		}
+
+		return a.InsertExperimentRun(sessCtx, run)
+	})
+}
+
+// UpsertInfra creates the infra document keyed by infraID, or $sets infra's
+// fields onto the existing one; fields absent from infra are left as they are.
+func (a *MongoDbAdapter) UpsertInfra(ctx context.Context, infraID string, infra interface{}) error {
+	filter := bson.M{"infra_id": infraID}
+
+	return a.track(ctx, Collections[ChaosInfraCollection], "UpdateOne", filter, func(ctx context.Context) error {
+		_, err := a.client.ChaosInfraCollection.UpdateOne(ctx, filter, bson.M{"$set": infra}, options.Update().SetUpsert(true))
+		return err
+	})
+}
+
+// GetInfraByID fetches the infra document keyed by infraID, returning
+// ErrNotFound - not the driver's mongo.ErrNoDocuments - when it doesn't
+// exist, so callers don't need to special-case the Mongo driver's error.
+func (a *MongoDbAdapter) GetInfraByID(ctx context.Context, infraID string) (bson.M, error) {
+	filter := bson.M{"infra_id": infraID}
+
+	var infra bson.M
+	err := a.track(ctx, Collections[ChaosInfraCollection], "FindOne", filter, func(ctx context.Context) error {
+		return a.client.ChaosInfraCollection.FindOne(ctx, filter).Decode(&infra)
+	})
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrNotFound
+	}
+
+	return infra, err
+}
+
+// UpsertHub creates the chaos hub document keyed by hubID, or $sets hub's
+// fields onto the existing one; fields absent from hub are left as they are.
+func (a *MongoDbAdapter) UpsertHub(ctx context.Context, hubID string, hub interface{}) error {
+	filter := bson.M{"hub_id": hubID}
+
+	return a.track(ctx, Collections[ChaosHubCollection], "UpdateOne", filter, func(ctx context.Context) error {
+		_, err := a.client.ChaosHubCollection.UpdateOne(ctx, filter, bson.M{"$set": hub}, options.Update().SetUpsert(true))
+		return err
+	})
+}
+
+// GetHubByID fetches the chaos hub document keyed by hubID, returning
+// ErrNotFound - not the driver's mongo.ErrNoDocuments - when it doesn't
+// exist, so callers don't need to special-case the Mongo driver's error.
+func (a *MongoDbAdapter) GetHubByID(ctx context.Context, hubID string) (bson.M, error) {
+	filter := bson.M{"hub_id": hubID}
+
+	var hub bson.M
+	err := a.track(ctx, Collections[ChaosHubCollection], "FindOne", filter, func(ctx context.Context) error {
+		return a.client.ChaosHubCollection.FindOne(ctx, filter).Decode(&hub)
+	})
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrNotFound
+	}
+
+	return hub, err
+}
+
+// ListHubsByProject returns every chaos hub registered under projectID.
+func (a *MongoDbAdapter) ListHubsByProject(ctx context.Context, projectID string) ([]bson.M, error) {
+	filter := bson.M{"project_id": projectID}
+
+	var hubs []bson.M
+	err := a.track(ctx, Collections[ChaosHubCollection], "Find", filter, func(ctx context.Context) error {
+		cursor, err := a.client.ChaosHubCollection.Find(ctx, filter)
+		if err != nil {
+			return err
+		}
+		return cursor.All(ctx, &hubs)
+	})
+
+	return hubs, err
+}
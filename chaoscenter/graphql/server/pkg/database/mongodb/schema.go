@@ -0,0 +1,398 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoDB server error codes that CollectionSpec reconciliation treats as
+// expected/non-fatal rather than startup failures.
+const (
+	errCodeNamespaceExists       = 48
+	errCodeIndexOptionsConflict  = 85
+	errCodeIndexKeySpecsConflict = 86
+)
+
+// CollectionSpec declares the desired shape of a single collection: the name
+// it should be created under, the indexes it should carry, and (optionally) a
+// $jsonSchema validator enforced on writes. EnsureCollections reconciles the
+// live database against a table of these specs so that repeated startups -
+// including concurrent ones from multiple chaoscenter replicas - converge on
+// the same state instead of racing on CreateCollection/CreateMany.
+type CollectionSpec struct {
+	Name             string
+	Indexes          []mongo.IndexModel
+	Validator        bson.M
+	ValidationLevel  string
+	ValidationAction string
+}
+
+// jsonSchemaValidator builds a $jsonSchema validator document requiring the
+// given fields to be present and of the given bsonType.
+func jsonSchemaValidator(required []string, properties bson.M) bson.M {
+	return bson.M{
+		"$jsonSchema": bson.M{
+			"bsonType":   "object",
+			"required":   required,
+			"properties": properties,
+		},
+	}
+}
+
+// collectionSpecs returns the declarative table of every collection
+// initAllCollection is responsible for, keyed off the Collections enum.
+func collectionSpecs() []CollectionSpec {
+	return []CollectionSpec{
+		{
+			Name: Collections[ChaosInfraCollection],
+			Indexes: []mongo.IndexModel{
+				{
+					Keys:    bson.D{{Key: "infra_id", Value: 1}},
+					Options: options.Index().SetUnique(true),
+				},
+				{
+					Keys: bson.D{{Key: "name", Value: 1}},
+				},
+			},
+			Validator: jsonSchemaValidator(
+				[]string{"infra_id", "name"},
+				bson.M{
+					"infra_id": bson.M{"bsonType": "string"},
+					"name":     bson.M{"bsonType": "string"},
+				},
+			),
+		},
+		{
+			Name: Collections[ChaosExperimentCollection],
+			Indexes: []mongo.IndexModel{
+				{
+					Keys:    bson.D{{Key: "experiment_id", Value: 1}},
+					Options: options.Index().SetUnique(true),
+				},
+				{
+					Keys: bson.D{{Key: "name", Value: 1}},
+				},
+			},
+			Validator: jsonSchemaValidator(
+				[]string{"experiment_id", "name"},
+				bson.M{
+					"experiment_id": bson.M{"bsonType": "string"},
+					"name":          bson.M{"bsonType": "string"},
+				},
+			),
+		},
+		{
+			Name: Collections[ChaosExperimentRunsCollection],
+			Indexes: []mongo.IndexModel{
+				{
+					Keys: bson.D{{Key: "experiment_run_id", Value: 1}},
+				},
+			},
+			Validator: jsonSchemaValidator(
+				[]string{"experiment_run_id"},
+				bson.M{
+					"experiment_run_id": bson.M{"bsonType": "string"},
+				},
+			),
+		},
+		{
+			Name: Collections[ChaosHubCollection],
+			Indexes: []mongo.IndexModel{
+				{
+					Keys:    bson.D{{Key: "hub_id", Value: 1}},
+					Options: options.Index().SetUnique(true),
+				},
+				{
+					Keys: bson.D{{Key: "name", Value: 1}},
+				},
+			},
+			Validator: jsonSchemaValidator(
+				[]string{"hub_id", "name"},
+				bson.M{
+					"hub_id": bson.M{"bsonType": "string"},
+					"name":   bson.M{"bsonType": "string"},
+				},
+			),
+		},
+		{
+			Name: Collections[GitOpsCollection],
+			Indexes: []mongo.IndexModel{
+				{
+					Keys:    bson.D{{Key: "project_id", Value: 1}},
+					Options: options.Index().SetUnique(true),
+				},
+			},
+		},
+		{
+			Name: Collections[ServerConfigCollection],
+			Indexes: []mongo.IndexModel{
+				{
+					Keys:    bson.D{{Key: "key", Value: 1}},
+					Options: options.Index().SetUnique(true),
+				},
+			},
+		},
+		{
+			Name: Collections[EnvironmentCollection],
+			Indexes: []mongo.IndexModel{
+				{
+					Keys:    bson.D{{Key: "environment_id", Value: 1}},
+					Options: options.Index().SetUnique(true),
+				},
+				{
+					Keys: bson.D{{Key: "name", Value: 1}},
+				},
+			},
+			Validator: jsonSchemaValidator(
+				[]string{"environment_id", "name"},
+				bson.M{
+					"environment_id": bson.M{"bsonType": "string"},
+					"name":           bson.M{"bsonType": "string"},
+				},
+			),
+		},
+		{Name: Collections[ImageRegistryCollection]},
+		{Name: Collections[UserCollection]},
+		{Name: Collections[ProjectCollection]},
+	}
+}
+
+// EnsureCollections reconciles the database against specs: it creates any
+// collection that doesn't exist yet (applying its validator, if any), updates
+// the validator on collections whose spec changed, and diffs the live indexes
+// against the spec's indexes, creating/dropping as needed. It is idempotent
+// and safe to call from every replica on every startup - NamespaceExists
+// errors on create are swallowed, and duplicate-key errors on index creation
+// are logged and swallowed, rather than treated as fatal.
+func (m *MongoClient) EnsureCollections(ctx context.Context, specs []CollectionSpec) error {
+	existing, err := m.Database.ListCollectionNames(ctx, bson.M{})
+	if err != nil {
+		return err
+	}
+
+	existingSet := make(map[string]bool, len(existing))
+	for _, name := range existing {
+		existingSet[name] = true
+	}
+
+	for _, spec := range specs {
+		if err := m.ensureCollection(ctx, spec, existingSet[spec.Name]); err != nil {
+			return err
+		}
+
+		coll := m.Database.Collection(spec.Name)
+		if err := ensureIndexes(ctx, coll, spec.Indexes); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ensureCollection creates the collection (applying its validator) if it
+// doesn't already exist, or runs collMod to bring an existing collection's
+// validator in line with the spec.
+func (m *MongoClient) ensureCollection(ctx context.Context, spec CollectionSpec, exists bool) error {
+	if !exists {
+		createOpts := options.CreateCollection()
+		if spec.Validator != nil {
+			createOpts.SetValidator(spec.Validator)
+			createOpts.SetValidationLevel(orDefault(spec.ValidationLevel, "moderate"))
+			createOpts.SetValidationAction(orDefault(spec.ValidationAction, "warn"))
+		}
+
+		err := m.Database.CreateCollection(ctx, spec.Name, createOpts)
+		if err != nil && !isServerError(err, errCodeNamespaceExists) {
+			logrus.WithError(err).Errorf("failed to create %s collection", spec.Name)
+			return err
+		}
+
+		return nil
+	}
+
+	if spec.Validator == nil {
+		return nil
+	}
+
+	cmd := bson.D{
+		{Key: "collMod", Value: spec.Name},
+		{Key: "validator", Value: spec.Validator},
+		{Key: "validationLevel", Value: orDefault(spec.ValidationLevel, "moderate")},
+		{Key: "validationAction", Value: orDefault(spec.ValidationAction, "warn")},
+	}
+	if err := m.Database.RunCommand(ctx, cmd).Err(); err != nil {
+		logrus.WithError(err).Errorf("failed to update validator for %s collection", spec.Name)
+		return err
+	}
+
+	return nil
+}
+
+// existingIndex is the subset of the listIndexes output ensureIndexes cares
+// about. Key is typed as bson.D (not bson.M) so decoding preserves the field
+// order the server reports it in - compound index field order is part of
+// its identity, and a bson.M target would lose it on the way through the
+// driver's map decode. Unique is included because it's the one option a spec
+// is likely to change on an existing index (e.g. loosening or tightening a
+// uniqueness constraint), and that drift must be visible to the diff below.
+type existingIndex struct {
+	Name   string `bson:"name"`
+	Key    bson.D `bson:"key"`
+	Unique bool   `bson:"unique"`
+}
+
+// ensureIndexes diffs the indexes already present on coll against want, keyed
+// by their key document rather than name (spec authors shouldn't have to
+// know the driver's auto-generated index names). Indexes present on the
+// collection but whose keys aren't in want at all are dropped as stale. A
+// wanted index whose keys and unique option both match an existing one is
+// left alone. When the keys match but the unique option has drifted from the
+// spec, CreateOne is still attempted: the server rejects the create as an
+// IndexOptionsConflict/IndexKeySpecsConflict, which is resolved below by
+// dropping the old index and recreating it from the spec. Matching on keys
+// alone and skipping CreateOne whenever they match would leave that option
+// drift undetected and unrepaired. Options other than unique are not compared.
+func ensureIndexes(ctx context.Context, coll *mongo.Collection, want []mongo.IndexModel) error {
+	cursor, err := coll.Indexes().List(ctx)
+	if err != nil {
+		return err
+	}
+
+	var existing []existingIndex
+	if err := cursor.All(ctx, &existing); err != nil {
+		return err
+	}
+
+	existingByKey := make(map[string]existingIndex, len(existing))
+	for _, idx := range existing {
+		if idx.Name == "_id_" {
+			continue
+		}
+		existingByKey[indexKeySignature(idx.Key)] = idx
+	}
+
+	wantKeySignatures := make(map[string]bool, len(want))
+	for _, idx := range want {
+		keys, ok := idx.Keys.(bson.D)
+		if !ok {
+			return fmt.Errorf("index on %s: Keys must be bson.D to keep compound-index field order stable, got %T", coll.Name(), idx.Keys)
+		}
+		keySig := indexKeySignature(keys)
+		wantKeySignatures[keySig] = true
+
+		if existingIdx, ok := existingByKey[keySig]; ok && existingIdx.Unique == isUnique(idx.Options) {
+			continue
+		}
+
+		_, err := coll.Indexes().CreateOne(ctx, idx)
+		if err == nil {
+			continue
+		}
+
+		if isServerError(err, errCodeIndexOptionsConflict) || isServerError(err, errCodeIndexKeySpecsConflict) {
+			if name, ok := conflictingIndexName(existing, keys); ok {
+				if _, dropErr := coll.Indexes().DropOne(ctx, name); dropErr != nil {
+					logrus.WithError(dropErr).Errorf("failed to drop conflicting index %s on %s", name, coll.Name())
+					return dropErr
+				}
+				if _, err = coll.Indexes().CreateOne(ctx, idx); err != nil {
+					logrus.WithError(err).Errorf("failed to recreate index on %s after dropping conflict", coll.Name())
+					return err
+				}
+				continue
+			}
+		}
+
+		if mongo.IsDuplicateKeyError(err) {
+			logrus.WithError(err).Warnf("duplicate key while creating index on %s", coll.Name())
+			continue
+		}
+
+		logrus.WithError(err).Errorf("failed to create index on %s", coll.Name())
+		return err
+	}
+
+	for keySig, idx := range existingByKey {
+		if wantKeySignatures[keySig] {
+			continue
+		}
+		if _, err := coll.Indexes().DropOne(ctx, idx.Name); err != nil {
+			logrus.WithError(err).Errorf("failed to drop stale index %s on %s", idx.Name, coll.Name())
+			return err
+		}
+	}
+
+	return nil
+}
+
+// indexKeySignature renders an index key document as a stable string so two
+// equivalent key documents (one from the spec, one decoded off the wire)
+// compare equal regardless of their concrete Go type. It walks keys in the
+// order given rather than sorting them - compound index field order is
+// semantically significant in MongoDB (an index on {a:1,b:1} is not
+// interchangeable with one on {b:1,a:1}), so two key documents must only be
+// considered equivalent when both their fields and field order match.
+func indexKeySignature(keys bson.D) string {
+	var b strings.Builder
+	for i, e := range keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, "%s:%v", e.Key, e.Value)
+	}
+	return b.String()
+}
+
+// isUnique reports whether opts asks for a unique index, tolerating a nil
+// *options.IndexOptions or a nil Unique field the same way the driver does:
+// as "not unique".
+func isUnique(opts *options.IndexOptions) bool {
+	return opts != nil && opts.Unique != nil && *opts.Unique
+}
+
+// conflictingIndexName finds the existing index with the given key document,
+// used to locate the index to drop when CreateOne reports an options/spec
+// conflict against an index with the same keys.
+func conflictingIndexName(existing []existingIndex, keys bson.D) (string, bool) {
+	sig := indexKeySignature(keys)
+	for _, idx := range existing {
+		if indexKeySignature(idx.Key) == sig {
+			return idx.Name, idx.Name != ""
+		}
+	}
+	return "", false
+}
+
+// isServerError reports whether err is a MongoDB command error carrying the
+// given server error code.
+func isServerError(err error, code int) bool {
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) {
+		return int(cmdErr.Code) == code
+	}
+
+	var writeException mongo.WriteException
+	if errors.As(err, &writeException) {
+		for _, we := range writeException.WriteErrors {
+			if we.Code == code {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,105 @@
+package mongodb
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestIndexKeySignature(t *testing.T) {
+	cases := []struct {
+		name      string
+		a, b      bson.D
+		wantEqual bool
+	}{
+		{
+			name:      "identical single-field keys",
+			a:         bson.D{{Key: "infra_id", Value: 1}},
+			b:         bson.D{{Key: "infra_id", Value: 1}},
+			wantEqual: true,
+		},
+		{
+			name:      "compound index field order is significant",
+			a:         bson.D{{Key: "a", Value: 1}, {Key: "b", Value: 1}},
+			b:         bson.D{{Key: "b", Value: 1}, {Key: "a", Value: 1}},
+			wantEqual: false,
+		},
+		{
+			name:      "different sort direction",
+			a:         bson.D{{Key: "a", Value: 1}},
+			b:         bson.D{{Key: "a", Value: -1}},
+			wantEqual: false,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			got := indexKeySignature(c.a) == indexKeySignature(c.b)
+			if got != c.wantEqual {
+				t.Fatalf("indexKeySignature(%v) == indexKeySignature(%v) = %v, want %v", c.a, c.b, got, c.wantEqual)
+			}
+		})
+	}
+}
+
+func TestIsUnique(t *testing.T) {
+	cases := []struct {
+		name string
+		opts *options.IndexOptions
+		want bool
+	}{
+		{name: "nil options", opts: nil, want: false},
+		{name: "unique unset", opts: options.Index(), want: false},
+		{name: "unique false", opts: options.Index().SetUnique(false), want: false},
+		{name: "unique true", opts: options.Index().SetUnique(true), want: true},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			if got := isUnique(c.opts); got != c.want {
+				t.Fatalf("isUnique(%+v) = %v, want %v", c.opts, got, c.want)
+			}
+		})
+	}
+}
+
+// TestEnsureIndexesRecreatesOnOptionDrift covers the case a key-only diff
+// misses entirely: an index already exists with the wanted keys but a stale
+// option (here, non-unique where the spec now wants unique). ensureIndexes
+// must still attempt CreateOne so the server's conflict response drives the
+// drop-and-recreate path, rather than treating the key match as "already
+// satisfied" and never touching the index.
+func TestEnsureIndexesRecreatesOnOptionDrift(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("same keys, drifted unique option", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(1, "db.coll", mtest.FirstBatch,
+			bson.D{{Key: "name", Value: "infra_id_1"}, {Key: "key", Value: bson.D{{Key: "infra_id", Value: 1}}}, {Key: "unique", Value: false}}))
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.coll", mtest.NextBatch))
+
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{
+			Code:    errCodeIndexOptionsConflict,
+			Message: "Index already exists with a different name",
+		}))
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		want := []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "infra_id", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+		}
+
+		if err := ensureIndexes(context.Background(), mt.Coll, want); err != nil {
+			t.Fatalf("ensureIndexes: %v", err)
+		}
+	})
+}
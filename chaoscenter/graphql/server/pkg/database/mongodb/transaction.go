@@ -0,0 +1,33 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// WithTransaction runs fn inside a multi-document ACID transaction. It wraps
+// Client.UseSessionWithOptions and Session.WithTransaction, which already
+// retries errors labeled TransientTransactionError and
+// UnknownTransactionCommitResult per the driver's transaction guidance - fn
+// may therefore run more than once and must be idempotent.
+//
+// Every operation fn performs must be passed sessCtx as its context so it
+// runs inside the transaction; DbAdapter methods already accept a plain
+// context.Context, so passing sessCtx straight through to them is enough to
+// make them transaction-aware. MongoDbAdapter.CreateExperimentWithRun is the
+// first caller: it closes the consistency bug where the experiment insert
+// and its initial run insert ran as two separate, unguarded writes and a
+// failure on the second could leave an orphan experiment with no initial run.
+// The project/user-create flow has no equivalent multi-write DbAdapter
+// method yet, so it isn't covered by this change.
+func (m *MongoClient) WithTransaction(ctx context.Context, fn func(sessCtx mongo.SessionContext) error) error {
+	return MgoClient.UseSessionWithOptions(ctx, options.Session(), func(sessCtx mongo.SessionContext) error {
+		_, err := sessCtx.WithTransaction(sessCtx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+			return nil, fn(sessCtx)
+		})
+
+		return err
+	})
+}
@@ -0,0 +1,72 @@
+package watcher
+
+import (
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Event is a single change-stream notification fanned out to subscribers.
+type Event struct {
+	Collection    string
+	OperationType string
+	DocumentKey   bson.M
+	FullDocument  bson.M
+}
+
+// Bus is a minimal topic-based pub/sub used to fan ChangeStream events out to
+// GraphQL subscription resolvers. Resolvers subscribe to the collection
+// they care about (e.g. "chaosExperimentRuns") instead of polling Mongo in a
+// tight loop for status changes.
+type Bus struct {
+	mu   sync.Mutex
+	subs map[string][]chan Event
+}
+
+// NewBus returns an empty, ready-to-use Bus.
+func NewBus() *Bus {
+	return &Bus{subs: make(map[string][]chan Event)}
+}
+
+// Subscribe registers a new subscriber for topic and returns a channel of
+// events plus an unsubscribe func the caller must invoke when done listening.
+func (b *Bus) Subscribe(topic string) (<-chan Event, func()) {
+	ch := make(chan Event, 16)
+
+	b.mu.Lock()
+	b.subs[topic] = append(b.subs[topic], ch)
+	b.mu.Unlock()
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		subs := b.subs[topic]
+		for i, sub := range subs {
+			if sub == ch {
+				b.subs[topic] = append(subs[:i], subs[i+1:]...)
+				close(ch)
+				break
+			}
+		}
+	}
+
+	return ch, unsubscribe
+}
+
+// Publish fans event out to every subscriber of topic. Events for a slow
+// subscriber are dropped rather than blocking the watch loop (the subscriber
+// itself stays registered): the channel buffer absorbs
+// bursts, but a subscriber that's stopped draining simply misses events.
+func (b *Bus) Publish(topic string, event Event) {
+	b.mu.Lock()
+	subs := append([]chan Event(nil), b.subs[topic]...)
+	b.mu.Unlock()
+
+	for _, ch := range subs {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package watcher
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBusPublishFanOut(t *testing.T) {
+	bus := NewBus()
+
+	ch1, unsubscribe1 := bus.Subscribe("chaosExperimentRuns")
+	defer unsubscribe1()
+	ch2, unsubscribe2 := bus.Subscribe("chaosExperimentRuns")
+	defer unsubscribe2()
+
+	bus.Publish("chaosExperimentRuns", Event{Collection: "chaosExperimentRuns", OperationType: "update"})
+
+	for i, ch := range []<-chan Event{ch1, ch2} {
+		select {
+		case got := <-ch:
+			if got.Collection != "chaosExperimentRuns" || got.OperationType != "update" {
+				t.Fatalf("subscriber %d got %+v, want collection=chaosExperimentRuns op=update", i, got)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("subscriber %d never received the published event", i)
+		}
+	}
+}
+
+func TestBusPublishIgnoresOtherTopics(t *testing.T) {
+	bus := NewBus()
+
+	ch, unsubscribe := bus.Subscribe("chaosExperimentRuns")
+	defer unsubscribe()
+
+	bus.Publish("chaosInfrastructures", Event{Collection: "chaosInfrastructures"})
+
+	select {
+	case got := <-ch:
+		t.Fatalf("subscriber to chaosExperimentRuns received %+v from a chaosInfrastructures publish", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestBusUnsubscribeClosesChannelAndStopsDelivery(t *testing.T) {
+	bus := NewBus()
+
+	ch, unsubscribe := bus.Subscribe("chaosExperimentRuns")
+	unsubscribe()
+
+	if _, ok := <-ch; ok {
+		t.Fatalf("channel should be closed once its subscriber has unsubscribed")
+	}
+
+	// Must not panic or block now that every subscriber for the topic is gone.
+	bus.Publish("chaosExperimentRuns", Event{})
+}
+
+func TestBusPublishDropsOnceSubscriberBufferFills(t *testing.T) {
+	bus := NewBus()
+
+	ch, unsubscribe := bus.Subscribe("chaosExperimentRuns")
+	defer unsubscribe()
+
+	// The channel Subscribe hands back buffers 16 events before Publish
+	// starts dropping rather than blocking the watch loop on a slow reader.
+	for i := 0; i < 32; i++ {
+		bus.Publish("chaosExperimentRuns", Event{OperationType: "update"})
+	}
+
+	received := 0
+drain:
+	for {
+		select {
+		case <-ch:
+			received++
+		default:
+			break drain
+		}
+	}
+
+	if received != 16 {
+		t.Fatalf("received %d buffered events, want 16 - events past the buffer should be dropped, not queued", received)
+	}
+}
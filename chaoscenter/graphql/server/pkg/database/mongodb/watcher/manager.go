@@ -0,0 +1,41 @@
+package watcher
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Manager owns one Watcher per watched collection and a shared Bus that
+// subscription resolvers read from.
+type Manager struct {
+	Bus      *Bus
+	watchers []*Watcher
+}
+
+// NewManager builds watchers for each of collections, all reporting into a
+// single Bus and persisting their resume state in serverConfig.
+func NewManager(serverConfig *mongo.Collection, collections ...*mongo.Collection) *Manager {
+	bus := NewBus()
+
+	watchers := make([]*Watcher, 0, len(collections))
+	for _, coll := range collections {
+		watchers = append(watchers, New(coll, serverConfig, bus))
+	}
+
+	return &Manager{Bus: bus, watchers: watchers}
+}
+
+// Start runs every watcher in its own goroutine. A watcher that errors out is
+// logged and left stopped rather than taking the others down with it; ctx
+// cancellation stops all of them.
+func (m *Manager) Start(ctx context.Context) {
+	for _, w := range m.watchers {
+		go func(w *Watcher) {
+			if err := w.Run(ctx); err != nil && ctx.Err() == nil {
+				logrus.WithError(err).Errorf("change stream watcher for %s stopped", w.Collection.Name())
+			}
+		}(w)
+	}
+}
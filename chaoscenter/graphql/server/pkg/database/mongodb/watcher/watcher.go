@@ -0,0 +1,202 @@
+// Package watcher drives GraphQL subscriptions off MongoDB change streams
+// instead of the polling loops the resolvers used previously. A Watcher
+// opens a ChangeStream on a single collection and fans every event out
+// through a Bus; subscription resolvers subscribe to the collection's topic
+// and receive push-based updates for experiment status, node phases, and
+// infra heartbeat changes. This requires the target deployment to be a
+// replica set.
+package watcher
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// changeStreamHistoryLost is the server error code returned when a resume
+// token has aged out of the oplog and can no longer be resumed from.
+const changeStreamHistoryLost = 286
+
+// resumeStateKey is the ServerConfigCollection key a Watcher's resume state
+// is persisted under, namespaced by the collection it watches so multiple
+// watchers can share one ServerConfigCollection.
+func resumeStateKey(collection string) string {
+	return "change_stream_resume_state:" + collection
+}
+
+// resumeState is the document persisted in ServerConfigCollection so a
+// restarted Watcher picks up where it left off instead of replaying the
+// collection's full history or missing events that happened while it was
+// down.
+type resumeState struct {
+	Key           string              `bson:"key"`
+	ResumeToken   bson.Raw            `bson:"resume_token,omitempty"`
+	OperationTime primitive.Timestamp `bson:"operation_time,omitempty"`
+	UpdatedAt     time.Time           `bson:"updated_at"`
+}
+
+// Watcher opens a ChangeStream on a single collection and publishes every
+// event onto Bus under Collection.Name() as the topic.
+type Watcher struct {
+	Collection   *mongo.Collection
+	ServerConfig *mongo.Collection
+	Bus          *Bus
+}
+
+// New returns a Watcher over collection, persisting its resume state into
+// serverConfig and publishing events onto bus.
+func New(collection, serverConfig *mongo.Collection, bus *Bus) *Watcher {
+	return &Watcher{Collection: collection, ServerConfig: serverConfig, Bus: bus}
+}
+
+// Run opens the change stream and blocks, publishing events until ctx is
+// cancelled or the stream errors out. Callers typically run this in its own
+// goroutine per watched collection.
+func (w *Watcher) Run(ctx context.Context) error {
+	stream, err := w.openStream(ctx)
+	if err != nil {
+		return err
+	}
+	defer stream.Close(ctx)
+
+	for stream.Next(ctx) {
+		var raw bson.M
+		if err := stream.Decode(&raw); err != nil {
+			logrus.WithError(err).Error("failed to decode change stream event")
+			continue
+		}
+
+		w.Bus.Publish(w.Collection.Name(), toEvent(raw))
+
+		if err := w.saveResumeState(ctx, stream.ResumeToken(), clusterTime(raw)); err != nil {
+			logrus.WithError(err).Error("failed to persist change stream resume token")
+		}
+	}
+
+	return stream.Err()
+}
+
+// openStream opens the change stream, resuming from the last persisted
+// resume token when one exists. If the token has been invalidated (the
+// oplog rolled past it), it falls back to startAtOperationTime using the
+// last-seen operation time, and finally to watching from "now".
+func (w *Watcher) openStream(ctx context.Context) (*mongo.ChangeStream, error) {
+	state, err := w.loadResumeState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	streamOpts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+	if state != nil && len(state.ResumeToken) > 0 {
+		streamOpts.SetResumeAfter(state.ResumeToken)
+	}
+
+	stream, err := w.Collection.Watch(ctx, mongo.Pipeline{}, streamOpts)
+	if err == nil {
+		return stream, nil
+	}
+
+	if !isChangeStreamHistoryLost(err) {
+		return nil, err
+	}
+
+	logrus.WithError(err).Warn("change stream resume token invalid, falling back to startAtOperationTime")
+
+	streamOpts = options.ChangeStream().SetFullDocument(options.UpdateLookup)
+	if state != nil && !state.OperationTime.IsZero() {
+		opTime := state.OperationTime
+		streamOpts.SetStartAtOperationTime(&opTime)
+
+		stream, err = w.Collection.Watch(ctx, mongo.Pipeline{}, streamOpts)
+		if err == nil || !isChangeStreamHistoryLost(err) {
+			return stream, err
+		}
+
+		logrus.WithError(err).Warn("change stream operation time no longer in oplog, watching from now")
+		streamOpts = options.ChangeStream().SetFullDocument(options.UpdateLookup)
+	}
+
+	return w.Collection.Watch(ctx, mongo.Pipeline{}, streamOpts)
+}
+
+func (w *Watcher) loadResumeState(ctx context.Context) (*resumeState, error) {
+	var state resumeState
+	err := w.ServerConfig.FindOne(ctx, bson.M{"key": resumeStateKey(w.Collection.Name())}).Decode(&state)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &state, nil
+}
+
+func (w *Watcher) saveResumeState(ctx context.Context, token bson.Raw, opTime primitive.Timestamp) error {
+	state := resumeState{
+		Key:           resumeStateKey(w.Collection.Name()),
+		ResumeToken:   token,
+		OperationTime: opTime,
+		UpdatedAt:     time.Now(),
+	}
+
+	_, err := w.ServerConfig.UpdateOne(
+		ctx,
+		bson.M{"key": state.Key},
+		bson.M{"$set": state},
+		options.Update().SetUpsert(true),
+	)
+
+	return err
+}
+
+// clusterTime extracts the change event's clusterTime, used as the
+// startAtOperationTime fallback if the resume token it's paired with is
+// later invalidated.
+func clusterTime(raw bson.M) primitive.Timestamp {
+	ts, ok := raw["clusterTime"].(primitive.Timestamp)
+	if !ok {
+		return primitive.Timestamp{}
+	}
+
+	return ts
+}
+
+func isChangeStreamHistoryLost(err error) bool {
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) {
+		return int(cmdErr.Code) == changeStreamHistoryLost
+	}
+
+	return false
+}
+
+func toEvent(raw bson.M) Event {
+	event := Event{}
+
+	if collection, ok := raw["ns"].(bson.M); ok {
+		if coll, ok := collection["coll"].(string); ok {
+			event.Collection = coll
+		}
+	}
+
+	if opType, ok := raw["operationType"].(string); ok {
+		event.OperationType = opType
+	}
+
+	if key, ok := raw["documentKey"].(bson.M); ok {
+		event.DocumentKey = key
+	}
+
+	if doc, ok := raw["fullDocument"].(bson.M); ok {
+		event.FullDocument = doc
+	}
+
+	return event
+}
@@ -0,0 +1,74 @@
+package watcher
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestResumeStateKeyNamespacesByCollection(t *testing.T) {
+	runs := resumeStateKey("chaosExperimentRuns")
+	infra := resumeStateKey("chaosInfrastructures")
+
+	if runs == infra {
+		t.Fatalf("resumeStateKey must differ per collection, got %q for both", runs)
+	}
+	if want := "change_stream_resume_state:chaosExperimentRuns"; runs != want {
+		t.Fatalf("resumeStateKey(\"chaosExperimentRuns\") = %q, want %q", runs, want)
+	}
+}
+
+func TestToEvent(t *testing.T) {
+	raw := bson.M{
+		"ns":            bson.M{"db": "litmus", "coll": "chaosExperimentRuns"},
+		"operationType": "update",
+		"documentKey":   bson.M{"_id": "abc"},
+		"fullDocument":  bson.M{"experiment_run_id": "run-1", "phase": "Running"},
+	}
+
+	event := toEvent(raw)
+
+	if event.Collection != "chaosExperimentRuns" {
+		t.Fatalf("Collection = %q, want chaosExperimentRuns", event.Collection)
+	}
+	if event.OperationType != "update" {
+		t.Fatalf("OperationType = %q, want update", event.OperationType)
+	}
+	if event.DocumentKey["_id"] != "abc" {
+		t.Fatalf("DocumentKey = %+v, want _id=abc", event.DocumentKey)
+	}
+	if event.FullDocument["phase"] != "Running" {
+		t.Fatalf("FullDocument = %+v, want phase=Running", event.FullDocument)
+	}
+}
+
+func TestToEventMissingFieldsYieldsZeroValue(t *testing.T) {
+	event := toEvent(bson.M{})
+
+	if event.Collection != "" || event.OperationType != "" || event.DocumentKey != nil || event.FullDocument != nil {
+		t.Fatalf("toEvent(empty document) = %+v, want a zero-value Event", event)
+	}
+}
+
+func TestIsChangeStreamHistoryLost(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil error", err: nil, want: false},
+		{name: "unrelated command error", err: mongo.CommandError{Code: 1, Message: "boom"}, want: false},
+		{name: "history lost code", err: mongo.CommandError{Code: changeStreamHistoryLost, Message: "resume point no longer in oplog"}, want: true},
+		{name: "non-command error", err: bson.ErrDecodeToNil, want: false},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			if got := isChangeStreamHistoryLost(c.err); got != c.want {
+				t.Fatalf("isChangeStreamHistoryLost(%v) = %v, want %v", c.err, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package utils
+
+// EnvironmentVariables holds the server configuration read from the
+// process environment at startup. Today it only models the fields the
+// database layer depends on.
+type EnvironmentVariables struct {
+	DbServer   string
+	DbUser     string
+	DbPassword string
+
+	// Connection-profile knobs for running against production-hardened
+	// MongoDB clusters: replica sets, mTLS/X.509-only deployments, and
+	// non-default read/write concerns or pool sizing.
+	ReplicaSet            string
+	TLSEnabled            bool
+	TLSCAFile             string
+	TLSCertificateKeyFile string
+	TLSInsecure           bool
+	AuthMechanism         string
+	ReadPreference        string
+	ReadConcern           string
+	WriteConcern          string
+	MaxPoolSize           int
+	MinPoolSize           int
+}
+
+// Config is the process-wide server configuration.
+var Config EnvironmentVariables